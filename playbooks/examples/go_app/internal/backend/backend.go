@@ -0,0 +1,34 @@
+// Package backend defines the pluggable inference backends the engine can
+// dispatch /predict calls to, and the request/response shapes they share.
+package backend
+
+import "context"
+
+// Request is the JSON body accepted by POST /predict: named input tensors,
+// each flattened to a slice of float64.
+type Request struct {
+	Inputs map[string][]float64 `json:"inputs"`
+}
+
+// Result is what a Backend produces for a single prediction: named output
+// tensors, in the same flattened shape as Request.Inputs.
+type Result struct {
+	Outputs map[string][]float64 `json:"outputs"`
+}
+
+// Backend serves predictions for a single loaded model. Implementations
+// must be safe for concurrent use.
+type Backend interface {
+	// Predict runs inference over req and returns the model's outputs.
+	Predict(ctx context.Context, req Request) (Result, error)
+
+	// Healthy reports whether the backend is currently able to serve
+	// Predict, e.g. that its session is still open or its container is
+	// still reachable. It is used by /readyz and must not itself count as
+	// a prediction.
+	Healthy(ctx context.Context) error
+
+	// Close releases any resources (open sessions, containers) held by the
+	// backend.
+	Close() error
+}
@@ -0,0 +1,113 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+// ONNX runs inference in-process against a model loaded from a local .onnx
+// file via ONNX Runtime. A single backend instance owns one session and
+// serializes calls to it, since onnxruntime_go sessions are not safe for
+// concurrent Run calls.
+type ONNX struct {
+	mu      sync.Mutex
+	session *ort.AdvancedSession
+	input   *ort.Tensor[float32]
+	output  *ort.Tensor[float32]
+	closed  bool
+}
+
+// NewONNX loads the model at path and prepares it for inference.
+func NewONNX(path string) (*ONNX, error) {
+	if err := ort.InitializeEnvironment(); err != nil {
+		return nil, fmt.Errorf("initializing onnxruntime: %w", err)
+	}
+
+	inputInfo, outputInfo, err := ort.GetInputOutputInfo(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading onnx model info for %s: %w", path, err)
+	}
+	if len(inputInfo) != 1 || len(outputInfo) != 1 {
+		return nil, fmt.Errorf("onnx backend only supports single input/output models, got %d/%d", len(inputInfo), len(outputInfo))
+	}
+
+	input, err := ort.NewEmptyTensor[float32](inputInfo[0].Dimensions)
+	if err != nil {
+		return nil, fmt.Errorf("allocating input tensor: %w", err)
+	}
+	output, err := ort.NewEmptyTensor[float32](outputInfo[0].Dimensions)
+	if err != nil {
+		input.Destroy()
+		return nil, fmt.Errorf("allocating output tensor: %w", err)
+	}
+
+	session, err := ort.NewAdvancedSession(path,
+		[]string{inputInfo[0].Name}, []string{outputInfo[0].Name},
+		[]ort.ArbitraryTensor{input}, []ort.ArbitraryTensor{output}, nil)
+	if err != nil {
+		input.Destroy()
+		output.Destroy()
+		return nil, fmt.Errorf("creating onnx session for %s: %w", path, err)
+	}
+
+	return &ONNX{session: session, input: input, output: output}, nil
+}
+
+// Predict implements Backend. req.Inputs must contain exactly one tensor,
+// matching the model's expected input shape.
+func (o *ONNX) Predict(ctx context.Context, req Request) (Result, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(req.Inputs) != 1 {
+		return Result{}, fmt.Errorf("onnx backend requires exactly one input tensor, got %d", len(req.Inputs))
+	}
+	var values []float64
+	for _, v := range req.Inputs {
+		values = v
+	}
+
+	dst := o.input.GetData()
+	if len(values) != len(dst) {
+		return Result{}, fmt.Errorf("input has %d values, model expects %d", len(values), len(dst))
+	}
+	for i, v := range values {
+		dst[i] = float32(v)
+	}
+
+	if err := o.session.Run(); err != nil {
+		return Result{}, fmt.Errorf("running onnx session: %w", err)
+	}
+
+	out := make([]float64, len(o.output.GetData()))
+	for i, v := range o.output.GetData() {
+		out[i] = float64(v)
+	}
+	return Result{Outputs: map[string][]float64{"output": out}}, nil
+}
+
+// Healthy implements Backend. It reports an error once the session has
+// been closed; onnxruntime_go offers no liveness check beyond that, since a
+// session that loaded successfully keeps running in-process.
+func (o *ONNX) Healthy(ctx context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.closed {
+		return fmt.Errorf("onnx session is closed")
+	}
+	return nil
+}
+
+// Close implements Backend.
+func (o *ONNX) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.session.Destroy()
+	o.input.Destroy()
+	o.output.Destroy()
+	o.closed = true
+	return nil
+}
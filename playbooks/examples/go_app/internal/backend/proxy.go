@@ -0,0 +1,73 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/containers/ansible-podman-collections/playbooks/examples/go_app/internal/modelmgr"
+)
+
+// maxErrorBodySnippet bounds how much of a non-2xx upstream body is quoted
+// in the returned error, so a large error page doesn't blow up the log.
+const maxErrorBodySnippet = 512
+
+// Proxy forwards predictions to a TorchServe or Triton container that the
+// engine itself started and manages through modelmgr. The container is
+// expected to accept and return the same JSON shape as Request/Result.
+type Proxy struct {
+	mgr  *modelmgr.Manager
+	name string
+}
+
+// NewProxy returns a Proxy backend that forwards to the container already
+// loaded under name in mgr.
+func NewProxy(mgr *modelmgr.Manager, name string) *Proxy {
+	return &Proxy{mgr: mgr, name: name}
+}
+
+// Predict implements Backend.
+func (p *Proxy) Predict(ctx context.Context, req Request) (Result, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("encoding request: %w", err)
+	}
+
+	resp, err := p.mgr.Predict(ctx, p.name, bytes.NewReader(body))
+	if err != nil {
+		return Result{}, fmt.Errorf("forwarding to %s: %w", p.name, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Result{}, fmt.Errorf("reading response from %s: %w", p.name, err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		snippet := data
+		if len(snippet) > maxErrorBodySnippet {
+			snippet = snippet[:maxErrorBodySnippet]
+		}
+		return Result{}, fmt.Errorf("%s returned status %d: %s", p.name, resp.StatusCode, snippet)
+	}
+
+	var result Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		return Result{}, fmt.Errorf("decoding response from %s: %w", p.name, err)
+	}
+	return result, nil
+}
+
+// Healthy implements Backend by checking that the container backing this
+// proxy still accepts connections on its predict port.
+func (p *Proxy) Healthy(ctx context.Context) error {
+	return p.mgr.Healthy(ctx, p.name)
+}
+
+// Close implements Backend. The underlying container is owned and torn
+// down by the Manager, not by the Proxy backend itself.
+func (p *Proxy) Close() error { return nil }
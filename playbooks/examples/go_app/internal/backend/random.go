@@ -0,0 +1,29 @@
+package backend
+
+import (
+	"context"
+	"math/rand"
+)
+
+// Random is the original stub backend: it ignores its input and returns a
+// single random float64 under the "output" key. It's registered as
+// backend=random and is primarily useful for exercising the engine's HTTP
+// plumbing without a real model.
+type Random struct{}
+
+// NewRandom returns a Random backend.
+func NewRandom() *Random {
+	return &Random{}
+}
+
+// Predict implements Backend.
+func (r *Random) Predict(ctx context.Context, req Request) (Result, error) {
+	return Result{Outputs: map[string][]float64{"output": {rand.Float64()}}}, nil
+}
+
+// Healthy implements Backend. Random has no session or container to fail,
+// so it is always healthy.
+func (r *Random) Healthy(ctx context.Context) error { return nil }
+
+// Close implements Backend.
+func (r *Random) Close() error { return nil }
@@ -0,0 +1,59 @@
+// Package config loads the YAML file (--config) describing which inference
+// backend serves each model.
+//
+// Per-model request batching (grouping concurrent predictions into one
+// underlying inference call) is intentionally out of scope: none of the
+// three backends batch, so there are no batch_size/batch_window fields
+// here to configure it.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ModelConfig describes one entry under the top-level "models" list.
+type ModelConfig struct {
+	// Name is how clients refer to this model, e.g. /predict?model=Name.
+	Name string `yaml:"name"`
+
+	// Backend selects the implementation: "random", "onnx", or "proxy".
+	Backend string `yaml:"backend"`
+
+	// Path is the local .onnx file, required when Backend is "onnx".
+	Path string `yaml:"path,omitempty"`
+
+	// Image is the container image to run, required when Backend is
+	// "proxy" (a TorchServe or Triton image the engine starts via
+	// modelmgr).
+	Image string `yaml:"image,omitempty"`
+}
+
+// Config is the top-level shape of the --config YAML file.
+type Config struct {
+	Models []ModelConfig `yaml:"models"`
+}
+
+// Load reads and parses the config file at path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+
+	for _, m := range cfg.Models {
+		switch m.Backend {
+		case "random", "onnx", "proxy":
+		default:
+			return nil, fmt.Errorf("model %s: unknown backend %q", m.Name, m.Backend)
+		}
+	}
+	return &cfg, nil
+}
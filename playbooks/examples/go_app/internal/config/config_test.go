@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing test config: %v", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeConfig(t, `
+models:
+  - name: stub
+    backend: random
+  - name: vision
+    backend: onnx
+    path: /models/vision.onnx
+  - name: bert
+    backend: proxy
+    image: docker.io/pytorch/torchserve:latest
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(cfg.Models) != 3 {
+		t.Fatalf("len(cfg.Models) = %d, want 3", len(cfg.Models))
+	}
+	if cfg.Models[0].Backend != "random" {
+		t.Errorf("Models[0].Backend = %q, want %q", cfg.Models[0].Backend, "random")
+	}
+	if cfg.Models[1].Path != "/models/vision.onnx" {
+		t.Errorf("Models[1].Path = %q, want %q", cfg.Models[1].Path, "/models/vision.onnx")
+	}
+	if cfg.Models[2].Image != "docker.io/pytorch/torchserve:latest" {
+		t.Errorf("Models[2].Image = %q, want %q", cfg.Models[2].Image, "docker.io/pytorch/torchserve:latest")
+	}
+}
+
+func TestLoadUnknownBackend(t *testing.T) {
+	path := writeConfig(t, `
+models:
+  - name: stub
+    backend: not-a-real-backend
+`)
+
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load with unknown backend: want error, got nil")
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("Load with missing file: want error, got nil")
+	}
+}
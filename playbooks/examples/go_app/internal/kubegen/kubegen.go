@@ -0,0 +1,119 @@
+// Package kubegen renders the Deployment+Service manifest for running the
+// inference engine under "podman play kube" or a Kubernetes cluster, wired
+// to the /healthz and /readyz probes.
+package kubegen
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// Options configures the generated manifest.
+type Options struct {
+	// Name is used for the Deployment, Service, and container name.
+	Name string
+
+	// Image is the inference engine's own container image.
+	Image string
+
+	// ModelImage is passed to the container as the plain env value
+	// MODEL_IMAGE, which the engine loads as its single proxy-backed model
+	// when started without --config.
+	ModelImage string
+
+	// Replicas is the Deployment's pod count.
+	Replicas int
+
+	// Port is the port the engine listens on and the Service targets.
+	Port int
+
+	// TerminationGracePeriodSeconds bounds how long the kubelet waits
+	// after SIGTERM before killing the pod, giving in-flight /predict
+	// calls time to drain.
+	TerminationGracePeriodSeconds int
+}
+
+// manifestTmpl renders a single-container Deployment and a ClusterIP
+// Service in front of it. POD_NAME is wired from the downward API so the
+// running engine can tag its logs with the pod that emitted them.
+var manifestTmpl = template.Must(template.New("manifest").Parse(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{.Name}}
+spec:
+  replicas: {{.Replicas}}
+  selector:
+    matchLabels:
+      app: {{.Name}}
+  template:
+    metadata:
+      labels:
+        app: {{.Name}}
+    spec:
+      terminationGracePeriodSeconds: {{.TerminationGracePeriodSeconds}}
+      containers:
+        - name: {{.Name}}
+          image: {{.Image}}
+          ports:
+            - containerPort: {{.Port}}
+          env:
+            - name: LISTEN_ADDR
+              value: ":{{.Port}}"
+            - name: MODEL_IMAGE
+              value: "{{.ModelImage}}"
+            - name: TERMINATION_GRACE_PERIOD
+              value: "{{.TerminationGracePeriodSeconds}}s"
+            - name: POD_NAME
+              valueFrom:
+                fieldRef:
+                  fieldPath: metadata.name
+          livenessProbe:
+            httpGet:
+              path: /healthz
+              port: {{.Port}}
+            initialDelaySeconds: 5
+            periodSeconds: 10
+          readinessProbe:
+            httpGet:
+              path: /readyz
+              port: {{.Port}}
+            initialDelaySeconds: 5
+            periodSeconds: 10
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{.Name}}
+spec:
+  selector:
+    app: {{.Name}}
+  ports:
+    - port: {{.Port}}
+      targetPort: {{.Port}}
+`))
+
+// Generate renders the manifest described by opts.
+func Generate(opts Options) (string, error) {
+	if opts.Name == "" {
+		opts.Name = "inference-engine"
+	}
+	if opts.Image == "" {
+		opts.Image = "inference-engine:latest"
+	}
+	if opts.Replicas <= 0 {
+		opts.Replicas = 1
+	}
+	if opts.Port <= 0 {
+		opts.Port = 8080
+	}
+	if opts.TerminationGracePeriodSeconds <= 0 {
+		opts.TerminationGracePeriodSeconds = 30
+	}
+
+	var buf bytes.Buffer
+	if err := manifestTmpl.Execute(&buf, opts); err != nil {
+		return "", fmt.Errorf("rendering kube manifest: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,55 @@
+package kubegen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateDefaults(t *testing.T) {
+	manifest, err := Generate(Options{})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	for _, want := range []string{
+		"kind: Deployment",
+		"kind: Service",
+		"name: inference-engine",
+		"image: inference-engine:latest",
+		"path: /healthz",
+		"path: /readyz",
+		"terminationGracePeriodSeconds: 30",
+		"fieldPath: metadata.name",
+	} {
+		if !strings.Contains(manifest, want) {
+			t.Errorf("manifest missing %q:\n%s", want, manifest)
+		}
+	}
+}
+
+func TestGenerateCustomOptions(t *testing.T) {
+	manifest, err := Generate(Options{
+		Name:                          "my-model",
+		Image:                         "example.com/my-model:v1",
+		ModelImage:                    "example.com/torchserve:v1",
+		Replicas:                      3,
+		Port:                          9090,
+		TerminationGracePeriodSeconds: 45,
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	for _, want := range []string{
+		"name: my-model",
+		"image: example.com/my-model:v1",
+		`value: "example.com/torchserve:v1"`,
+		"replicas: 3",
+		"containerPort: 9090",
+		"terminationGracePeriodSeconds: 45",
+	} {
+		if !strings.Contains(manifest, want) {
+			t.Errorf("manifest missing %q:\n%s", want, manifest)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Package metrics exposes Prometheus collectors for the inference engine:
+// application-level prediction counters/latencies, and container-level
+// CPU/memory gauges fed by the Podman stats scraper in scraper.go.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	predictionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "inference_predictions_total",
+		Help: "Total number of /predict requests, by model and outcome.",
+	}, []string{"model", "status"})
+
+	predictionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "inference_prediction_duration_seconds",
+		Help:    "Latency of /predict requests, by model.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"model"})
+
+	containerCPUPercent = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "inference_container_cpu_percent",
+		Help: "CPU usage percent of a model's container, as reported by Podman.",
+	}, []string{"model"})
+
+	containerMemoryBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "inference_container_memory_bytes",
+		Help: "Resident memory usage of a model's container, as reported by Podman.",
+	}, []string{"model"})
+)
+
+// ObservePrediction records the outcome and latency of a /predict call for
+// model.
+func ObservePrediction(model string, err error, duration time.Duration) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	predictionsTotal.WithLabelValues(model, status).Inc()
+	predictionDuration.WithLabelValues(model).Observe(duration.Seconds())
+}
+
+// SetContainerStats updates the CPU/memory gauges for model's container.
+func SetContainerStats(model string, cpuPercent float64, memoryBytes uint64) {
+	containerCPUPercent.WithLabelValues(model).Set(cpuPercent)
+	containerMemoryBytes.WithLabelValues(model).Set(float64(memoryBytes))
+}
+
+// DeleteContainerStats removes the CPU/memory gauges for model, used when a
+// model is unloaded so stale series don't linger on the scrape.
+func DeleteContainerStats(model string) {
+	containerCPUPercent.DeleteLabelValues(model)
+	containerMemoryBytes.DeleteLabelValues(model)
+}
+
+// Handler returns the http.Handler to mount at /metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/containers/ansible-podman-collections/playbooks/examples/go_app/internal/modelmgr"
+)
+
+// DefaultStatsTimeout bounds how long the scraper waits for a single stats
+// frame from Podman before treating the stream as stalled and reconnecting.
+const DefaultStatsTimeout = 5 * time.Second
+
+// Scraper keeps the container CPU/memory gauges in sync with Podman's
+// per-container stats stream for every model currently loaded in mgr.
+type Scraper struct {
+	mgr     *modelmgr.Manager
+	timeout time.Duration
+}
+
+// NewScraper returns a Scraper that reconnects a stats stream whenever a
+// read blocks for longer than timeout. A timeout of zero selects
+// DefaultStatsTimeout.
+func NewScraper(mgr *modelmgr.Manager, timeout time.Duration) *Scraper {
+	if timeout <= 0 {
+		timeout = DefaultStatsTimeout
+	}
+	return &Scraper{mgr: mgr, timeout: timeout}
+}
+
+// Run polls mgr for loaded models every interval and ensures each has a
+// stats-streaming goroutine, until ctx is cancelled.
+func (s *Scraper) Run(ctx context.Context, interval time.Duration) {
+	watching := make(map[string]context.CancelFunc)
+	defer func() {
+		for _, cancel := range watching {
+			cancel()
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		models := make(map[string]bool)
+		for _, model := range s.mgr.List() {
+			models[model.Name] = true
+			if _, ok := watching[model.Name]; !ok {
+				watchCtx, cancel := context.WithCancel(ctx)
+				watching[model.Name] = cancel
+				go s.watch(watchCtx, model.Name)
+			}
+		}
+		for name, cancel := range watching {
+			if !models[name] {
+				cancel()
+				delete(watching, name)
+				DeleteContainerStats(name)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// watch keeps a single model's stats gauges up to date, reconnecting the
+// stream whenever a read stalls past s.timeout or the stream ends.
+func (s *Scraper) watch(ctx context.Context, model string) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Each attempt gets its own cancelable context so a stalled or
+		// abandoned stream can be torn down before the next attempt opens
+		// a new one; reusing ctx here would leave the old StreamStats
+		// goroutine blocked forever trying to send on a channel nobody
+		// reads anymore.
+		attemptCtx, cancel := context.WithCancel(ctx)
+
+		frames, err := s.mgr.StreamStats(attemptCtx, model)
+		if err != nil {
+			cancel()
+			log.Printf("metrics: opening stats stream for %s: %v", model, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(s.timeout):
+			}
+			continue
+		}
+
+		eof := false
+	readLoop:
+		for {
+			select {
+			case <-ctx.Done():
+				cancel()
+				return
+			case stats, ok := <-frames:
+				if !ok {
+					eof = true
+					break readLoop
+				}
+				SetContainerStats(model, stats.CPUPercent, stats.MemoryBytes)
+			case <-time.After(s.timeout):
+				log.Printf("metrics: stats stream for %s stalled past %s, reconnecting", model, s.timeout)
+				break readLoop
+			}
+		}
+		cancel()
+
+		if eof {
+			// Unlike the stall case above, the stream closing (EOF) isn't
+			// itself throttled by s.timeout, so without an explicit wait
+			// here a container whose stream keeps closing immediately
+			// would spin reconnecting as fast as the loop can run.
+			log.Printf("metrics: stats stream for %s ended, reconnecting in %s", model, s.timeout)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(s.timeout):
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Package middleware provides http.Handler wrappers for request
+// correlation and access logging, shared by every route the inference
+// engine registers.
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"time"
+
+	"github.com/containers/ansible-podman-collections/playbooks/examples/go_app/internal/reqid"
+)
+
+// Chain applies middlewares to h in the order given, so the first
+// middleware listed runs outermost.
+func Chain(h http.Handler, middlewares ...func(http.Handler) http.Handler) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+// RequestID echoes the incoming X-Reference-Id header back to the client,
+// generating a ULID when the client didn't send one, and stores it in the
+// request's context so downstream handlers and logging can pick it up via
+// reqid.FromContext.
+//
+// This is deliberately a per-request middleware rather than wired through
+// http.Server.BaseContext/ConnContext: a kept-alive connection serves many
+// requests, each needing its own id, so deriving the id once per connection
+// there would tie every request on that connection to the same reference.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := reqid.FromRequest(r)
+		w.Header().Set(reqid.Header, id)
+		r = r.WithContext(reqid.WithID(r.Context(), id))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AccessLog emits one Apache combined-log-format line per request, with the
+// reference id appended so it can be joined against the trace logs below.
+func AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		log.Printf("%s - - [%s] %q %d %d %q %q ref=%s",
+			r.RemoteAddr,
+			start.Format("02/Jan/2006:15:04:05 -0700"),
+			r.Method+" "+r.RequestURI+" "+r.Proto,
+			rec.status,
+			rec.bytes,
+			r.Referer(),
+			r.UserAgent(),
+			reqid.FromContext(r.Context()),
+		)
+	})
+}
+
+// TraceBody logs the full request and response bodies at trace level, keyed
+// by the request's reference id. It is relatively expensive (it buffers both
+// bodies in memory) and is meant for debugging a specific correlated
+// request, not for steady-state production traffic, so callers should only
+// include it in the chain when the TRACE_BODIES env var opts in.
+func TraceBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := reqid.FromContext(r.Context())
+
+		if dump, err := httputil.DumpRequest(r, true); err == nil {
+			log.Printf("trace ref=%s request=%q", id, dump)
+		}
+
+		rec := &bodyRecorder{statusRecorder: statusRecorder{ResponseWriter: w, status: http.StatusOK}}
+		next.ServeHTTP(rec, r)
+		log.Printf("trace ref=%s response_status=%d response_body=%q", id, rec.status, rec.body.String())
+	})
+}
+
+// statusRecorder captures the status code and byte count written through an
+// http.ResponseWriter so AccessLog can report them after the handler runs.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// bodyRecorder additionally tees the response body into an in-memory buffer
+// for TraceBody.
+type bodyRecorder struct {
+	statusRecorder
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.statusRecorder.Write(b)
+}
+
+var _ io.Writer = (*bodyRecorder)(nil)
@@ -0,0 +1,274 @@
+// Package modelmgr manages the lifecycle of model-serving containers on a
+// local Podman service (rootful or rootless) and proxies prediction
+// requests to them.
+package modelmgr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	nettypes "github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v4/pkg/bindings"
+	"github.com/containers/podman/v4/pkg/bindings/containers"
+	"github.com/containers/podman/v4/pkg/bindings/images"
+	"github.com/containers/podman/v4/pkg/specgen"
+)
+
+// ContainerStats is a trimmed-down view of a single Podman stats frame for
+// a model's container.
+type ContainerStats struct {
+	CPUPercent  float64
+	MemoryBytes uint64
+}
+
+// DefaultSocket is the default Podman API socket, matching the rootful
+// default. Rootless users typically point at
+// $XDG_RUNTIME_DIR/podman/podman.sock instead.
+const DefaultSocket = "/run/podman/podman.sock"
+
+// Model describes a running model server container.
+type Model struct {
+	Name        string `json:"name"`
+	Image       string `json:"image"`
+	ContainerID string `json:"container_id"`
+	Port        int    `json:"port"`
+}
+
+// Manager pulls, starts, health-checks, and tears down model containers on
+// a Podman service, and proxies prediction traffic to them.
+type Manager struct {
+	conn   context.Context
+	client *http.Client
+
+	mu     sync.RWMutex
+	models map[string]*Model
+}
+
+// NewManager connects to the Podman API at socketPath (e.g.
+// "unix:///run/podman/podman.sock") and returns a Manager ready to load
+// models. The connection is reused for every subsequent call.
+func NewManager(socketPath string) (*Manager, error) {
+	conn, err := bindings.NewConnection(context.Background(), socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to podman socket %s: %w", socketPath, err)
+	}
+	return &Manager{
+		conn:   conn,
+		client: &http.Client{Timeout: 10 * time.Second},
+		models: make(map[string]*Model),
+	}, nil
+}
+
+// Load pulls image, starts it bound to an ephemeral host port, waits for it
+// to answer health checks, and registers it under name. Loading a name that
+// is already loaded stops and removes the previous container first, so the
+// new one can take the same deterministic container name.
+func (m *Manager) Load(ctx context.Context, name, image string) (*Model, error) {
+	if _, ok := m.Get(name); ok {
+		if err := m.Unload(ctx, name); err != nil {
+			return nil, fmt.Errorf("replacing existing model %s: %w", name, err)
+		}
+	}
+
+	if _, err := images.Pull(m.conn, image, nil); err != nil {
+		return nil, fmt.Errorf("pulling image %s: %w", image, err)
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return nil, fmt.Errorf("allocating port for %s: %w", name, err)
+	}
+
+	s := specgen.NewSpecGenerator(image, false)
+	s.Name = fmt.Sprintf("modelmgr-%s", name)
+	s.PortMappings = []nettypes.PortMapping{{
+		ContainerPort: 8080,
+		HostPort:      uint16(port),
+	}}
+
+	created, err := containers.CreateWithSpec(m.conn, s, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating container for %s: %w", name, err)
+	}
+	if err := containers.Start(m.conn, created.ID, nil); err != nil {
+		return nil, fmt.Errorf("starting container for %s: %w", name, err)
+	}
+
+	model := &Model{Name: name, Image: image, ContainerID: created.ID, Port: port}
+	if err := m.waitHealthy(ctx, model); err != nil {
+		_, _ = containers.Remove(m.conn, created.ID, &containers.RemoveOptions{Force: boolPtr(true)})
+		return nil, fmt.Errorf("model %s never became healthy: %w", name, err)
+	}
+
+	m.mu.Lock()
+	m.models[name] = model
+	m.mu.Unlock()
+	return model, nil
+}
+
+// List returns the currently loaded models.
+func (m *Manager) List() []*Model {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]*Model, 0, len(m.models))
+	for _, model := range m.models {
+		out = append(out, model)
+	}
+	return out
+}
+
+// Get returns the loaded model registered under name, if any.
+func (m *Manager) Get(name string) (*Model, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	model, ok := m.models[name]
+	return model, ok
+}
+
+// Unload stops and removes the container backing name.
+func (m *Manager) Unload(ctx context.Context, name string) error {
+	m.mu.Lock()
+	model, ok := m.models[name]
+	if ok {
+		delete(m.models, name)
+	}
+	m.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("model %s is not loaded", name)
+	}
+
+	stopTimeout := uint(10)
+	if err := containers.Stop(m.conn, model.ContainerID, &containers.StopOptions{Timeout: &stopTimeout}); err != nil {
+		return fmt.Errorf("stopping container for %s: %w", name, err)
+	}
+	if _, err := containers.Remove(m.conn, model.ContainerID, &containers.RemoveOptions{Force: boolPtr(true)}); err != nil {
+		return fmt.Errorf("removing container for %s: %w", name, err)
+	}
+	return nil
+}
+
+// Shutdown unloads every loaded model, used on engine shutdown.
+func (m *Manager) Shutdown(ctx context.Context) {
+	for _, model := range m.List() {
+		if err := m.Unload(ctx, model.Name); err != nil {
+			fmt.Printf("modelmgr: shutdown: unloading %s: %v\n", model.Name, err)
+		}
+	}
+}
+
+// StreamStats opens Podman's streaming container stats endpoint for name's
+// container and returns a channel of decoded frames. The channel is closed
+// when ctx is cancelled or the model is unloaded.
+func (m *Manager) StreamStats(ctx context.Context, name string) (<-chan ContainerStats, error) {
+	model, ok := m.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("model %s is not loaded", name)
+	}
+
+	stream := true
+	reports, err := containers.Stats(m.conn, []string{model.ContainerID}, &containers.StatsOptions{Stream: &stream})
+	if err != nil {
+		return nil, fmt.Errorf("opening stats stream for %s: %w", name, err)
+	}
+
+	out := make(chan ContainerStats)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case report, ok := <-reports:
+				if !ok {
+					return
+				}
+				for _, s := range report.Stats {
+					select {
+					case out <- ContainerStats{CPUPercent: s.CPU, MemoryBytes: s.MemUsage}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// Predict proxies body to the model's container and returns its response.
+func (m *Manager) Predict(ctx context.Context, name string, body io.Reader) (*http.Response, error) {
+	model, ok := m.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("model %s is not loaded", name)
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := io.Copy(buf, body); err != nil {
+		return nil, fmt.Errorf("reading request body: %w", err)
+	}
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/predict", model.Port)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, buf)
+	if err != nil {
+		return nil, err
+	}
+	return m.client.Do(req)
+}
+
+// waitHealthy polls the container's predict port until it accepts TCP
+// connections, ctx is done, or 30 seconds have passed.
+func (m *Manager) waitHealthy(ctx context.Context, model *Model) error {
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		if err := dialModel(model); err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+	return fmt.Errorf("timed out waiting for %s to listen on %s", model.Name, addrFor(model))
+}
+
+// Healthy reports whether name's container currently accepts TCP
+// connections on its predict port, with a single attempt (unlike
+// waitHealthy, which retries for up to 30 seconds).
+func (m *Manager) Healthy(ctx context.Context, name string) error {
+	model, ok := m.Get(name)
+	if !ok {
+		return fmt.Errorf("model %s is not loaded", name)
+	}
+	return dialModel(model)
+}
+
+func addrFor(model *Model) string {
+	return fmt.Sprintf("127.0.0.1:%d", model.Port)
+}
+
+func dialModel(model *Model) error {
+	conn, err := net.DialTimeout("tcp", addrFor(model), time.Second)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// freePort asks the kernel for an unused TCP port.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+func boolPtr(b bool) *bool { return &b }
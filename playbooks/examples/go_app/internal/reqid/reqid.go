@@ -0,0 +1,43 @@
+// Package reqid carries a request correlation id (from the X-Reference-Id
+// header, or a freshly generated ULID) through a request's context.Context
+// so every log line for that request can be tied back together.
+package reqid
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type contextKey struct{}
+
+// Header is the name of the request/response header carrying the id.
+const Header = "X-Reference-Id"
+
+// New generates a fresh ULID-based reference id.
+func New() string {
+	return ulid.MustNew(ulid.Timestamp(time.Now()), rand.Reader).String()
+}
+
+// FromRequest returns r's reference id, generating one if the incoming
+// request didn't supply X-Reference-Id.
+func FromRequest(r *http.Request) string {
+	if id := r.Header.Get(Header); id != "" {
+		return id
+	}
+	return New()
+}
+
+// WithID returns a context carrying id, retrievable via FromContext.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the reference id stored in ctx, or "" if none was set.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
@@ -1,19 +1,353 @@
+// Command inference-engine is an example model-serving HTTP API that
+// dispatches predictions to Podman-managed model containers.
+//
+// Because it links github.com/containers/podman/v4's bindings, it needs
+// the same build tags podman itself recommends for consumers that don't
+// need every storage/image backend:
+//
+//	go build -tags "containers_image_openpgp exclude_graphdriver_btrfs exclude_graphdriver_devicemapper" ./...
 package main
 
 import (
-    "fmt"
-    "math/rand"
-    "net/http"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/containers/ansible-podman-collections/playbooks/examples/go_app/internal/backend"
+	"github.com/containers/ansible-podman-collections/playbooks/examples/go_app/internal/config"
+	"github.com/containers/ansible-podman-collections/playbooks/examples/go_app/internal/kubegen"
+	"github.com/containers/ansible-podman-collections/playbooks/examples/go_app/internal/metrics"
+	"github.com/containers/ansible-podman-collections/playbooks/examples/go_app/internal/middleware"
+	"github.com/containers/ansible-podman-collections/playbooks/examples/go_app/internal/modelmgr"
+)
+
+const (
+	defaultShutdownTimeout = 30 * time.Second
+	statsPollInterval      = 15 * time.Second
+)
+
+var (
+	mgr *modelmgr.Manager
+
+	backendsMu sync.RWMutex
+	backends   = map[string]backend.Backend{}
 )
 
+// predictResponse is the JSON body returned by a successful /predict call.
+type predictResponse struct {
+	Model     string               `json:"model"`
+	Outputs   map[string][]float64 `json:"outputs"`
+	LatencyMS float64              `json:"latency_ms"`
+}
+
+// loadRequest is the body of a POST /models request.
+type loadRequest struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+}
+
+// loadBackends builds a Backend for every entry in cfg, starting any
+// container a proxy backend needs via mgr. It returns the assembled
+// registry keyed by model name.
+func loadBackends(ctx context.Context, cfg *config.Config) (map[string]backend.Backend, error) {
+	registry := make(map[string]backend.Backend, len(cfg.Models))
+	for _, m := range cfg.Models {
+		switch m.Backend {
+		case "random":
+			registry[m.Name] = backend.NewRandom()
+
+		case "onnx":
+			b, err := backend.NewONNX(m.Path)
+			if err != nil {
+				return nil, fmt.Errorf("model %s: %w", m.Name, err)
+			}
+			registry[m.Name] = b
+
+		case "proxy":
+			if _, err := mgr.Load(ctx, m.Name, m.Image); err != nil {
+				return nil, fmt.Errorf("model %s: %w", m.Name, err)
+			}
+			registry[m.Name] = backend.NewProxy(mgr, m.Name)
+		}
+	}
+	return registry, nil
+}
+
+func modelsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(mgr.List())
+
+	case http.MethodPost:
+		var req loadRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		model, err := mgr.Load(r.Context(), req.Name, req.Image)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		backendsMu.Lock()
+		backends[req.Name] = backend.NewProxy(mgr, req.Name)
+		backendsMu.Unlock()
+		json.NewEncoder(w).Encode(model)
+
+	case http.MethodDelete:
+		name := r.URL.Query().Get("name")
+		if err := mgr.Unload(r.Context(), name); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		backendsMu.Lock()
+		delete(backends, name)
+		backendsMu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
 func predict(w http.ResponseWriter, r *http.Request) {
-    fmt.Fprintf(w, "prediction=%f\n", rand.Float64())
+	name := r.URL.Query().Get("model")
+	if name == "" {
+		http.Error(w, "missing model query parameter", http.StatusBadRequest)
+		return
+	}
+
+	backendsMu.RLock()
+	b, ok := backends[name]
+	backendsMu.RUnlock()
+	if !ok {
+		http.Error(w, fmt.Sprintf("model %s is not loaded", name), http.StatusNotFound)
+		return
+	}
+
+	var req backend.Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	start := time.Now()
+	result, err := b.Predict(r.Context(), req)
+	latency := time.Since(start)
+	metrics.ObservePrediction(name, err, latency)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	json.NewEncoder(w).Encode(predictResponse{
+		Model:     name,
+		Outputs:   result.Outputs,
+		LatencyMS: float64(latency.Microseconds()) / 1000,
+	})
+}
+
+// healthz is the liveness probe: it reports healthy as soon as the process
+// is able to serve HTTP at all, regardless of backend state.
+func healthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, "ok")
+}
+
+// readyz is the readiness probe: it only reports ready once at least one
+// loaded model backend also answers Healthy, so a pod isn't sent traffic
+// before it has a model that's actually able to predict.
+func readyz(w http.ResponseWriter, r *http.Request) {
+	backendsMu.RLock()
+	snapshot := make(map[string]backend.Backend, len(backends))
+	for name, b := range backends {
+		snapshot[name] = b
+	}
+	backendsMu.RUnlock()
+
+	for _, b := range snapshot {
+		if err := b.Healthy(r.Context()); err == nil {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintln(w, "ok")
+			return
+		}
+	}
+	http.Error(w, "no healthy model backend", http.StatusServiceUnavailable)
 }
 
 func main() {
-    http.HandleFunc("/predict", predict)
-    fmt.Println("Inference engine listening on :8080")
-    http.ListenAndServe(":8080", nil)
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := runGenerate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	configPath := flag.String("config", "", "path to the backend config YAML file")
+	flag.Parse()
+
+	if podName := os.Getenv("POD_NAME"); podName != "" {
+		log.SetPrefix("[" + podName + "] ")
+	}
+
+	socket := os.Getenv("PODMAN_SOCKET")
+	if socket == "" {
+		socket = "unix://" + modelmgr.DefaultSocket
+	}
+
+	var err error
+	mgr, err = modelmgr.NewManager(socket)
+	if err != nil {
+		log.Fatalf("connecting to podman: %v", err)
+	}
+
+	switch {
+	case *configPath != "":
+		cfg, err := config.Load(*configPath)
+		if err != nil {
+			log.Fatalf("loading config: %v", err)
+		}
+		loaded, err := loadBackends(context.Background(), cfg)
+		if err != nil {
+			log.Fatalf("loading backends: %v", err)
+		}
+		backendsMu.Lock()
+		backends = loaded
+		backendsMu.Unlock()
+
+	case os.Getenv("MODEL_IMAGE") != "":
+		// No --config in this deployment mode; MODEL_IMAGE (set as a plain
+		// env value in contrib/kube/inference.yaml) names the single
+		// proxy-backed model to start under the fixed name "default".
+		image := os.Getenv("MODEL_IMAGE")
+		if _, err := mgr.Load(context.Background(), "default", image); err != nil {
+			log.Fatalf("loading model from MODEL_IMAGE=%s: %v", image, err)
+		}
+		backendsMu.Lock()
+		backends["default"] = backend.NewProxy(mgr, "default")
+		backendsMu.Unlock()
+	}
+
+	statsTimeout := metrics.DefaultStatsTimeout
+	if v := os.Getenv("STATS_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			statsTimeout = d
+		} else {
+			log.Printf("invalid STATS_TIMEOUT %q, using default %s: %v", v, statsTimeout, err)
+		}
+	}
+
+	scraperCtx, stopScraper := context.WithCancel(context.Background())
+	go metrics.NewScraper(mgr, statsTimeout).Run(scraperCtx, statsPollInterval)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/predict", predict)
+	mux.HandleFunc("/models", modelsHandler)
+	mux.Handle("/metrics", metrics.Handler())
+	mux.HandleFunc("/healthz", healthz)
+	mux.HandleFunc("/readyz", readyz)
+
+	chain := []func(http.Handler) http.Handler{middleware.RequestID, middleware.AccessLog}
+	if traceBodies, _ := strconv.ParseBool(os.Getenv("TRACE_BODIES")); traceBodies {
+		chain = append(chain, middleware.TraceBody)
+	}
+	handler := middleware.Chain(mux, chain...)
+
+	listenAddr := os.Getenv("LISTEN_ADDR")
+	if listenAddr == "" {
+		listenAddr = ":8080"
+	}
+
+	srv := &http.Server{
+		Addr:         listenAddr,
+		Handler:      handler,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+
+	shutdownTimeout := defaultShutdownTimeout
+	if v := os.Getenv("TERMINATION_GRACE_PERIOD"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			shutdownTimeout = d
+		} else {
+			log.Printf("invalid TERMINATION_GRACE_PERIOD %q, using default %s: %v", v, shutdownTimeout, err)
+		}
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		<-sigCh
+		stopScraper()
+		// srv.Shutdown blocks until every in-flight /predict (and other)
+		// call returns or ctx expires, so this is also where the
+		// termination grace period is enforced.
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Printf("server shutdown: %v", err)
+		}
+		for name, b := range backends {
+			if err := b.Close(); err != nil {
+				log.Printf("closing backend %s: %v", name, err)
+			}
+		}
+		mgr.Shutdown(ctx)
+	}()
+
+	fmt.Printf("Inference engine listening on %s\n", listenAddr)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+	// ListenAndServe returns as soon as Shutdown starts; wait for the drain
+	// and backend teardown above to finish before exiting.
+	<-shutdownDone
 }
 
+// runGenerate implements the "generate" subcommand family, currently just
+// "generate kube".
+func runGenerate(args []string) error {
+	if len(args) == 0 || args[0] != "kube" {
+		return fmt.Errorf("usage: inference-engine generate kube [flags]")
+	}
+
+	fs := flag.NewFlagSet("generate kube", flag.ExitOnError)
+	name := fs.String("name", "inference-engine", "name used for the Deployment, Service, and container")
+	image := fs.String("image", "inference-engine:latest", "container image for the inference engine itself")
+	modelImage := fs.String("model-image", "", "image passed to the pod as MODEL_IMAGE")
+	replicas := fs.Int("replicas", 1, "Deployment replica count")
+	port := fs.Int("port", 8080, "port the engine listens on and the Service targets")
+	gracePeriod := fs.Int("grace-period", 30, "termination grace period in seconds")
+	output := fs.String("o", "", "file to write the manifest to (default: stdout)")
+	fs.Parse(args[1:])
 
+	manifest, err := kubegen.Generate(kubegen.Options{
+		Name:                          *name,
+		Image:                         *image,
+		ModelImage:                    *modelImage,
+		Replicas:                      *replicas,
+		Port:                          *port,
+		TerminationGracePeriodSeconds: *gracePeriod,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		fmt.Print(manifest)
+		return nil
+	}
+	return os.WriteFile(*output, []byte(manifest), 0o644)
+}
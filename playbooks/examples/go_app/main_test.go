@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/containers/ansible-podman-collections/playbooks/examples/go_app/internal/backend"
+)
+
+func TestPredictRandomBackendRoundTrip(t *testing.T) {
+	backendsMu.Lock()
+	backends["stub"] = backend.NewRandom()
+	backendsMu.Unlock()
+	defer func() {
+		backendsMu.Lock()
+		delete(backends, "stub")
+		backendsMu.Unlock()
+	}()
+
+	body, err := json.Marshal(backend.Request{Inputs: map[string][]float64{"x": {1, 2, 3}}})
+	if err != nil {
+		t.Fatalf("marshaling request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/predict?model=stub", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	predict(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("predict status = %d, body = %q", rec.Code, rec.Body.String())
+	}
+
+	var resp predictResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if resp.Model != "stub" {
+		t.Errorf("resp.Model = %q, want %q", resp.Model, "stub")
+	}
+	if len(resp.Outputs["output"]) != 1 {
+		t.Errorf("resp.Outputs[output] = %v, want one value", resp.Outputs["output"])
+	}
+}
+
+func TestPredictUnknownModel(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/predict?model=does-not-exist", bytes.NewReader([]byte(`{}`)))
+	rec := httptest.NewRecorder()
+	predict(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("predict status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}